@@ -0,0 +1,142 @@
+// Package agent implements the client and wire protocol for a remote Aleo
+// signing agent: a small out-of-process daemon that holds private key
+// material and exposes key generation and signing over a Unix domain socket,
+// so the calling process never sees raw key bytes. This mirrors how `docker
+// build --ssh` forwards $SSH_AUTH_SOCK so the build container never sees the
+// key material. The reference server lives in cmd/aleo-signer-agent.
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Op identifies the operation requested of the agent.
+type Op string
+
+const (
+	OpNewKey        Op = "new_key"
+	OpSign          Op = "sign"
+	OpList          Op = "list"
+	OpSignByAddress Op = "sign_by_address"
+)
+
+// Request is the newline-delimited JSON message sent to the agent over the
+// socket. Handle and Message are base64-encoded, since they carry arbitrary
+// bytes.
+type Request struct {
+	Op      Op     `json:"op"`
+	Handle  string `json:"handle,omitempty"`
+	Message string `json:"message,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// Response is the newline-delimited JSON reply from the agent. Handle is
+// base64-encoded and is an opaque reference to a key held by the agent, never
+// the raw private key bytes.
+type Response struct {
+	Error     string   `json:"error,omitempty"`
+	Address   string   `json:"address,omitempty"`
+	Handle    string   `json:"handle,omitempty"`
+	Signature string   `json:"signature,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Client talks to a remote signing agent over a Unix domain socket. It
+// satisfies aleo_utils.Signer structurally, without importing that package:
+// the handle returned from NewPrivateKeyContext is an opaque reference
+// understood only by the agent on the other end of the socket.
+type Client struct {
+	socketPath string
+}
+
+// Dial returns a Client that connects to the agent listening on socketPath.
+// Each call opens a short-lived connection, matching the low-frequency,
+// high-value nature of signing requests.
+func Dial(socketPath string) *Client {
+	return &Client{socketPath: socketPath}
+}
+
+func (c *Client) call(ctx context.Context, req Request) (Response, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return Response{}, fmt.Errorf("aleo-signer-agent: dial %s: %w", c.socketPath, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return Response{}, fmt.Errorf("aleo-signer-agent: send request: %w", err)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return Response{}, fmt.Errorf("aleo-signer-agent: read response: %w", err)
+	}
+	if resp.Error != "" {
+		return Response{}, errors.New(resp.Error)
+	}
+
+	return resp, nil
+}
+
+// NewPrivateKeyContext asks the agent to generate a new private key. The
+// returned key is an opaque handle, not the raw private key bytes; it is only
+// meaningful in later calls against the same agent.
+func (c *Client) NewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error) {
+	resp, err := c.call(ctx, Request{Op: OpNewKey})
+	if err != nil {
+		return nil, "", err
+	}
+	handle, err := base64.StdEncoding.DecodeString(resp.Handle)
+	if err != nil {
+		return nil, "", fmt.Errorf("aleo-signer-agent: decode handle: %w", err)
+	}
+	return handle, resp.Address, nil
+}
+
+// SignContext asks the agent to sign message using the key referenced by
+// handle, as returned from NewPrivateKeyContext.
+func (c *Client) SignContext(ctx context.Context, handle []byte, message []byte) (signature string, err error) {
+	resp, err := c.call(ctx, Request{
+		Op:      OpSign,
+		Handle:  base64.StdEncoding.EncodeToString(handle),
+		Message: base64.StdEncoding.EncodeToString(message),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Signature, nil
+}
+
+// ListAddresses returns the addresses of every key currently held by the agent.
+func (c *Client) ListAddresses(ctx context.Context) ([]string, error) {
+	resp, err := c.call(ctx, Request{Op: OpList})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Addresses, nil
+}
+
+// SignByAddress asks the agent to sign message using the key for address,
+// so the caller doesn't need to retain the opaque handle for it.
+func (c *Client) SignByAddress(ctx context.Context, address string, message []byte) (signature string, err error) {
+	resp, err := c.call(ctx, Request{
+		Op:      OpSignByAddress,
+		Address: address,
+		Message: base64.StdEncoding.EncodeToString(message),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,288 @@
+package aleo_utils
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// SignBatch signs every message in messages with key. See the Session
+// interface doc for the strategy used.
+func (s *aleoWrapperSession) SignBatch(key []byte, messages [][]byte) (signatures []string, err error) {
+	return s.SignBatchContext(context.Background(), key, messages)
+}
+
+// SignBatchContext is SignBatch, but passes ctx into the underlying wasm calls.
+func (s *aleoWrapperSession) SignBatchContext(ctx context.Context, key []byte, messages [][]byte) (signatures []string, err error) {
+	if s.mod == nil || s.mod.IsClosed() {
+		return nil, ErrNoModule
+	}
+	if len(key) != PRIVATE_KEY_SIZE {
+		return nil, errors.New("invalid private key size")
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch x := r.(type) {
+			case string:
+				err = errors.New(x)
+			case error:
+				err = x
+			default:
+				err = errors.New("unknown panic")
+			}
+			signatures = nil
+		}
+	}()
+
+	if s.signMany != nil && s.capabilities.SupportsBatch {
+		return s.signManyContext(ctx, key, messages)
+	}
+
+	maxLen := 0
+	for _, message := range messages {
+		if len(message) > maxLen {
+			maxLen = len(message)
+		}
+	}
+
+	// Grow the scratch buffer once to the largest message, and reuse it for
+	// every call instead of allocating per message.
+	scratchPtr, _, err := s.allocateSafe(ctx, uint64(maxLen))
+	if err != nil {
+		s.logf("error", "batch scratch buffer allocate error", "err", err)
+		return nil, errors.New("failed to allocate scratch buffer for batch signing")
+	}
+	defer func() {
+		if err := s.deallocateSafe(ctx, scratchPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate batch scratch buffer", "err", err)
+		}
+	}()
+
+	// Reuse the private-key region across the whole batch, and wipe it once at
+	// the end instead of once per signature.
+	privateKeyPtr, _, err := s.allocateSafe(ctx, PRIVATE_KEY_SIZE)
+	if err != nil {
+		s.logf("error", "private key allocate error", "err", err)
+		return nil, errors.New("failed to allocate memory for private key")
+	}
+	defer func() {
+		zero := make([]byte, PRIVATE_KEY_SIZE)
+		_ = s.mod.Memory().Write(uint32(privateKeyPtr), zero)
+		if err := s.deallocateSafe(ctx, privateKeyPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate private key memory in SignBatch", "err", err)
+		}
+	}()
+
+	if ok := s.mod.Memory().Write(uint32(privateKeyPtr), key); !ok {
+		return nil, errors.New("failed to write private key to memory for batch signing")
+	}
+
+	signatures = make([]string, len(messages))
+	for i, message := range messages {
+		if ok := s.mod.Memory().Write(uint32(scratchPtr), message); !ok {
+			return nil, fmt.Errorf("failed to write message %d to scratch buffer for signing", i)
+		}
+
+		signaturePtr, err := s.sign.Call(ctx, privateKeyPtr, PRIVATE_KEY_SIZE, scratchPtr, uint64(len(message)))
+		if err != nil {
+			s.logf("error", "sign error", "err", err)
+			return nil, fmt.Errorf("failed to sign message %d", i)
+		}
+		if len(signaturePtr) == 0 {
+			return nil, fmt.Errorf("internal error when signing message %d: empty return", i)
+		}
+		sigPtr, sigLen := decodeLenPtr(signaturePtr[0])
+		if sigPtr == 0 || sigLen == 0 {
+			return nil, fmt.Errorf("internal error when signing message %d", i)
+		}
+
+		sig, ok := s.mod.Memory().Read(sigPtr, sigLen)
+		if !ok {
+			return nil, fmt.Errorf("failed to read signature for message %d", i)
+		}
+		signatures[i] = string(sig)
+		if _, err := s.deallocate.Call(ctx, uint64(sigPtr), uint64(sigLen)); err != nil {
+			s.logf("error", "failed to deallocate signature memory in SignBatch", "err", err)
+		}
+	}
+
+	return signatures, nil
+}
+
+// signManyContext signs the whole batch in a single wasm call using the
+// module's sign_many export, writing every message into one contiguous
+// buffer alongside a (length<<32|offset)-encoded offsets table, and reading
+// back count*SIGNATURE_SIZE bytes of concatenated signatures.
+func (s *aleoWrapperSession) signManyContext(ctx context.Context, key []byte, messages [][]byte) (signatures []string, err error) {
+	total := 0
+	for _, message := range messages {
+		total += len(message)
+	}
+
+	msgsPtr, _, err := s.allocateSafe(ctx, uint64(total))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate messages buffer for sign_many: %w", err)
+	}
+	defer func() {
+		if err := s.deallocateSafe(ctx, msgsPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate sign_many messages buffer", "err", err)
+		}
+	}()
+
+	offsets := make([]byte, 0, len(messages)*8)
+	offset := uint32(0)
+	for _, message := range messages {
+		if ok := s.mod.Memory().Write(uint32(msgsPtr)+offset, message); !ok {
+			return nil, errors.New("failed to write messages buffer for sign_many")
+		}
+		encoded := uint64(offset) | uint64(len(message))<<32
+		offsets = binary.LittleEndian.AppendUint64(offsets, encoded)
+		offset += uint32(len(message))
+	}
+
+	offsetsPtr, _, err := s.allocateSafe(ctx, uint64(len(offsets)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate offsets buffer for sign_many: %w", err)
+	}
+	defer func() {
+		if err := s.deallocateSafe(ctx, offsetsPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate sign_many offsets buffer", "err", err)
+		}
+	}()
+	if ok := s.mod.Memory().Write(uint32(offsetsPtr), offsets); !ok {
+		return nil, errors.New("failed to write offsets buffer for sign_many")
+	}
+
+	privateKeyPtr, _, err := s.allocateSafe(ctx, PRIVATE_KEY_SIZE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate private key for sign_many: %w", err)
+	}
+	defer func() {
+		zero := make([]byte, PRIVATE_KEY_SIZE)
+		_ = s.mod.Memory().Write(uint32(privateKeyPtr), zero)
+		if err := s.deallocateSafe(ctx, privateKeyPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate private key memory in sign_many", "err", err)
+		}
+	}()
+	if ok := s.mod.Memory().Write(uint32(privateKeyPtr), key); !ok {
+		return nil, errors.New("failed to write private key for sign_many")
+	}
+
+	result, err := s.signMany.Call(ctx, privateKeyPtr, PRIVATE_KEY_SIZE, msgsPtr, offsetsPtr, uint64(len(messages)))
+	if err != nil {
+		return nil, fmt.Errorf("sign_many call failed: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("sign_many: empty return")
+	}
+
+	resultPtr, resultLen := decodeLenPtr(result[0])
+	if resultPtr == 0 || resultLen == 0 {
+		return nil, errors.New("sign_many: invalid return")
+	}
+	defer s.deallocate.Call(ctx, uint64(resultPtr), uint64(resultLen))
+
+	buf, ok := s.mod.Memory().Read(resultPtr, resultLen)
+	if !ok {
+		return nil, errors.New("sign_many: failed to read signatures")
+	}
+	if resultLen != uint32(len(messages))*SIGNATURE_SIZE {
+		return nil, fmt.Errorf("sign_many: unexpected result length %d for %d messages", resultLen, len(messages))
+	}
+
+	signatures = make([]string, len(messages))
+	for i := range messages {
+		start := i * SIGNATURE_SIZE
+		signatures[i] = string(buf[start : start+SIGNATURE_SIZE])
+	}
+
+	return signatures, nil
+}
+
+// HashMessageBatch hashes every message in messages, reusing a single scratch
+// buffer across the batch instead of allocating one per message.
+func (s *aleoWrapperSession) HashMessageBatch(messages [][]byte) (hashes [][]byte, err error) {
+	return s.HashMessageBatchContext(context.Background(), messages)
+}
+
+// HashMessageBatchContext is HashMessageBatch, but passes ctx into the underlying wasm calls.
+func (s *aleoWrapperSession) HashMessageBatchContext(ctx context.Context, messages [][]byte) (hashes [][]byte, err error) {
+	if s.mod == nil || s.mod.IsClosed() {
+		return nil, ErrNoModule
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch x := r.(type) {
+			case string:
+				err = errors.New(x)
+			case error:
+				err = x
+			default:
+				err = errors.New("unknown panic")
+			}
+			hashes = nil
+		}
+	}()
+
+	maxLen := 0
+	for _, message := range messages {
+		if len(message) > maxLen {
+			maxLen = len(message)
+		}
+	}
+
+	scratchPtr, _, err := s.allocateSafe(ctx, uint64(maxLen))
+	if err != nil {
+		s.logf("error", "batch scratch buffer allocate error", "err", err)
+		return nil, errors.New("failed to allocate scratch buffer for batch hashing")
+	}
+	defer func() {
+		if err := s.deallocateSafe(ctx, scratchPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate batch scratch buffer", "err", err)
+		}
+	}()
+
+	hashes = make([][]byte, len(messages))
+	for i, message := range messages {
+		if ok := s.mod.Memory().Write(uint32(scratchPtr), message); !ok {
+			return nil, fmt.Errorf("failed to write message %d to scratch buffer for hashing", i)
+		}
+
+		hashResult, err := s.hashMessageBytes.Call(ctx, scratchPtr, uint64(len(message)))
+		if err != nil {
+			s.logf("error", "hash message bytes error", "err", err)
+			return nil, fmt.Errorf("failed to hash message %d", i)
+		}
+		if len(hashResult) == 0 {
+			return nil, fmt.Errorf("invalid message %d: empty return", i)
+		}
+
+		hashPtr, hashLen := decodeLenPtr(hashResult[0])
+		if hashPtr == 0 || hashLen == 0 {
+			return nil, fmt.Errorf("invalid message %d", i)
+		}
+
+		buf, ok := s.mod.Memory().Read(hashPtr, hashLen)
+		if !ok {
+			return nil, fmt.Errorf("failed to read hash for message %d", i)
+		}
+		hash := make([]byte, len(buf))
+		copy(hash, buf)
+		hashes[i] = hash
+
+		if _, err := s.deallocate.Call(ctx, uint64(hashPtr), uint64(hashLen)); err != nil {
+			s.logf("error", "failed to deallocate hash memory in HashMessageBatch", "err", err)
+		}
+	}
+
+	return hashes, nil
+}
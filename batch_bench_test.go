@@ -0,0 +1,78 @@
+package aleo_utils_test
+
+import (
+	"testing"
+
+	aleo "github.com/venture23-aleo/aleo-utils-go"
+)
+
+// BenchmarkSignIndividual signs each message in the batch with its own Sign call,
+// for comparison against BenchmarkSignBatch.
+func BenchmarkSignIndividual(b *testing.B) {
+	wrapper, closeFn, err := aleo.NewWrapper()
+	if err != nil {
+		b.Fatalf("create wrapper: %v", err)
+	}
+	defer closeFn()
+
+	s, err := wrapper.NewSession()
+	if err != nil {
+		b.Fatalf("create session: %v", err)
+	}
+	defer s.Close()
+
+	privKey, _, err := s.NewPrivateKey()
+	if err != nil {
+		b.Fatalf("create private key: %v", err)
+	}
+
+	messages := benchmarkMessages()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, message := range messages {
+			if _, err := s.Sign(privKey, message); err != nil {
+				b.Fatalf("sign message: %v", err)
+			}
+		}
+	}
+}
+
+// BenchmarkSignBatch signs the same batch of messages with one SignBatch call,
+// for comparison against BenchmarkSignIndividual.
+func BenchmarkSignBatch(b *testing.B) {
+	wrapper, closeFn, err := aleo.NewWrapper()
+	if err != nil {
+		b.Fatalf("create wrapper: %v", err)
+	}
+	defer closeFn()
+
+	s, err := wrapper.NewSession()
+	if err != nil {
+		b.Fatalf("create session: %v", err)
+	}
+	defer s.Close()
+
+	privKey, _, err := s.NewPrivateKey()
+	if err != nil {
+		b.Fatalf("create private key: %v", err)
+	}
+
+	messages := benchmarkMessages()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.SignBatch(privKey, messages); err != nil {
+			b.Fatalf("sign batch: %v", err)
+		}
+	}
+}
+
+func benchmarkMessages() [][]byte {
+	const count = 100
+	messages := make([][]byte, count)
+	for i := range messages {
+		messages[i] = []byte("btc/usd = 1.0")
+	}
+	return messages
+}
@@ -0,0 +1,122 @@
+package aleo_utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tetratelabs/wazero/api"
+)
+
+// MaxSupportedABIMajor is the highest WASM ABI major version this wrapper
+// understands. NewSession rejects a module whose aleo_abi_version() reports a
+// newer major version, since a major bump signals a breaking wire change.
+const MaxSupportedABIMajor = 1
+
+// Capabilities describes what the loaded WASM module actually supports,
+// negotiated at session creation by calling its optional aleo_abi_version and
+// aleo_capabilities exports — borrowing the shape of 9P's Tversion/Rversion
+// handshake, where the client learns what the server actually supports rather
+// than assuming fixed behavior. Modules that don't export this pair report the
+// v0 profile below, matching every release before this negotiation existed.
+type Capabilities struct {
+	ABIVersion      string
+	MaxFormatChunks int
+	BlockSize       int
+	HashAlgos       []string
+	SupportsBatch   bool
+}
+
+// v0Capabilities is reported for modules that don't export aleo_abi_version or
+// aleo_capabilities, using today's hardcoded constants.
+var v0Capabilities = Capabilities{
+	ABIVersion:      "v0",
+	MaxFormatChunks: MAX_FORMAT_MESSAGE_CHUNKS,
+	BlockSize:       MESSAGE_FORMAT_BLOCK_SIZE,
+	HashAlgos:       []string{"poseidon8"},
+	SupportsBatch:   false,
+}
+
+// capabilitiesWire is the JSON shape returned by aleo_capabilities().
+type capabilitiesWire struct {
+	MaxFormatChunks int      `json:"max_format_chunks"`
+	BlockSize       int      `json:"block_size"`
+	HashAlgos       []string `json:"hash_algos"`
+	SupportsBatch   bool     `json:"supports_batch"`
+}
+
+// negotiateCapabilities calls the module's optional aleo_abi_version and
+// aleo_capabilities exports and returns what was negotiated, falling back to
+// v0Capabilities if either export is absent. It returns an error if the
+// module's major ABI version is newer than MaxSupportedABIMajor.
+func negotiateCapabilities(ctx context.Context, mod api.Module, deallocate api.Function) (Capabilities, error) {
+	abiVersionFn := mod.ExportedFunction("aleo_abi_version")
+	capabilitiesFn := mod.ExportedFunction("aleo_capabilities")
+	if abiVersionFn == nil || capabilitiesFn == nil {
+		return v0Capabilities, nil
+	}
+
+	version, err := readWasmString(ctx, mod, abiVersionFn, deallocate)
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("failed to read aleo_abi_version: %w", err)
+	}
+
+	major, err := abiMajor(version)
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("invalid aleo_abi_version %q: %w", version, err)
+	}
+	if major > MaxSupportedABIMajor {
+		return Capabilities{}, fmt.Errorf("wasm module ABI major version %d is newer than the %d this wrapper understands", major, MaxSupportedABIMajor)
+	}
+
+	raw, err := readWasmString(ctx, mod, capabilitiesFn, deallocate)
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("failed to read aleo_capabilities: %w", err)
+	}
+
+	var wire capabilitiesWire
+	if err := json.Unmarshal([]byte(raw), &wire); err != nil {
+		return Capabilities{}, fmt.Errorf("failed to parse aleo_capabilities: %w", err)
+	}
+
+	return Capabilities{
+		ABIVersion:      version,
+		MaxFormatChunks: wire.MaxFormatChunks,
+		BlockSize:       wire.BlockSize,
+		HashAlgos:       wire.HashAlgos,
+		SupportsBatch:   wire.SupportsBatch,
+	}, nil
+}
+
+func abiMajor(version string) (int, error) {
+	version = strings.TrimPrefix(version, "v")
+	major, _, _ := strings.Cut(version, ".")
+	return strconv.Atoi(major)
+}
+
+// readWasmString calls fn with no arguments, expecting a (len<<32|ptr) encoded
+// return like the rest of the module's exports, reads the resulting string out
+// of wasm memory, and deallocates it.
+func readWasmString(ctx context.Context, mod api.Module, fn, deallocate api.Function) (string, error) {
+	result, err := fn.Call(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", errors.New("empty return")
+	}
+	ptr, length := decodeLenPtr(result[0])
+	if ptr == 0 || length == 0 {
+		return "", errors.New("invalid pointer")
+	}
+	defer deallocate.Call(ctx, uint64(ptr), uint64(length))
+
+	buf, ok := mod.Memory().Read(ptr, length)
+	if !ok {
+		return "", errors.New("failed to read memory")
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,197 @@
+// Command aleo-signer-agent is a reference implementation of the remote
+// signing daemon that github.com/venture23-aleo/aleo-utils-go/agent.Client
+// talks to. It holds generated private keys in memory, behind opaque handles,
+// and exposes key generation and signing over a Unix domain socket so that a
+// calling process using agent.Client as its Session Signer never sees raw key
+// bytes.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+
+	aleo "github.com/venture23-aleo/aleo-utils-go"
+	"github.com/venture23-aleo/aleo-utils-go/agent"
+)
+
+// keyStore holds generated private keys behind opaque handles, so the wire
+// protocol never needs to carry raw key bytes.
+type keyStore struct {
+	mu     sync.Mutex
+	byHand map[string][]byte // handle (base64) -> private key bytes
+	addr   map[string]string // address -> handle (base64)
+}
+
+func newKeyStore() *keyStore {
+	return &keyStore{
+		byHand: make(map[string][]byte),
+		addr:   make(map[string]string),
+	}
+}
+
+func (k *keyStore) put(key []byte, address string) (handle string) {
+	raw := make([]byte, 16)
+	_, _ = rand.Read(raw)
+	handle = base64.StdEncoding.EncodeToString(raw)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.byHand[handle] = key
+	k.addr[address] = handle
+	return handle
+}
+
+func (k *keyStore) byHandle(handle string) ([]byte, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.byHand[handle]
+	return key, ok
+}
+
+func (k *keyStore) byAddress(address string) ([]byte, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	handle, ok := k.addr[address]
+	if !ok {
+		return nil, false
+	}
+	key, ok := k.byHand[handle]
+	return key, ok
+}
+
+func (k *keyStore) addresses() []string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	out := make([]string, 0, len(k.addr))
+	for address := range k.addr {
+		out = append(out, address)
+	}
+	return out
+}
+
+// server dispatches concurrent client connections against a shared pool of
+// wasm sessions. aleo.Session is explicitly documented as not goroutine-safe,
+// so each request acquires its own session from the pool for the duration of
+// the call instead of sharing one across the goroutines spawned per connection.
+type server struct {
+	pool *aleo.SessionPool
+	keys *keyStore
+}
+
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	var req agent.Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		_ = json.NewEncoder(conn).Encode(agent.Response{Error: err.Error()})
+		return
+	}
+
+	resp := s.dispatch(req)
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		log.Printf("aleo-signer-agent: failed to write response: %v", err)
+	}
+}
+
+func (s *server) dispatch(req agent.Request) agent.Response {
+	session, release, err := s.pool.Acquire(context.Background())
+	if err != nil {
+		return agent.Response{Error: fmt.Sprintf("acquire session: %v", err)}
+	}
+	defer release()
+
+	switch req.Op {
+	case agent.OpNewKey:
+		key, address, err := session.NewPrivateKey()
+		if err != nil {
+			return agent.Response{Error: err.Error()}
+		}
+		handle := s.keys.put(key, address)
+		return agent.Response{Address: address, Handle: handle}
+
+	case agent.OpList:
+		return agent.Response{Addresses: s.keys.addresses()}
+
+	case agent.OpSign:
+		message, err := base64.StdEncoding.DecodeString(req.Message)
+		if err != nil {
+			return agent.Response{Error: "invalid message encoding"}
+		}
+		key, ok := s.keys.byHandle(req.Handle)
+		if !ok {
+			return agent.Response{Error: "unknown key handle"}
+		}
+		signature, err := session.Sign(key, message)
+		if err != nil {
+			return agent.Response{Error: err.Error()}
+		}
+		return agent.Response{Signature: signature}
+
+	case agent.OpSignByAddress:
+		message, err := base64.StdEncoding.DecodeString(req.Message)
+		if err != nil {
+			return agent.Response{Error: "invalid message encoding"}
+		}
+		key, ok := s.keys.byAddress(req.Address)
+		if !ok {
+			return agent.Response{Error: "unknown address"}
+		}
+		signature, err := session.Sign(key, message)
+		if err != nil {
+			return agent.Response{Error: err.Error()}
+		}
+		return agent.Response{Signature: signature}
+
+	default:
+		return agent.Response{Error: "unknown op: " + string(req.Op)}
+	}
+}
+
+func main() {
+	socketPath := flag.String("socket", "/run/aleo-signer-agent.sock", "path of the Unix domain socket to listen on")
+	poolSize := flag.Int("sessions", 4, "number of wasm sessions to keep warm for concurrent clients")
+	flag.Parse()
+
+	wrapper, closeFn, err := aleo.NewWrapper()
+	if err != nil {
+		log.Fatalf("aleo-signer-agent: create wrapper: %v", err)
+	}
+	defer closeFn()
+
+	pool, err := wrapper.NewPool(*poolSize)
+	if err != nil {
+		log.Fatalf("aleo-signer-agent: create session pool: %v", err)
+	}
+	defer pool.Close()
+
+	if err := os.RemoveAll(*socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("aleo-signer-agent: remove stale socket: %v", err)
+	}
+
+	listener, err := net.Listen("unix", *socketPath)
+	if err != nil {
+		log.Fatalf("aleo-signer-agent: listen on %s: %v", *socketPath, err)
+	}
+	defer listener.Close()
+
+	log.Printf("aleo-signer-agent: listening on %s", *socketPath)
+
+	s := &server{pool: pool, keys: newKeyStore()}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("aleo-signer-agent: accept: %v", err)
+			continue
+		}
+		go s.handle(conn)
+	}
+}
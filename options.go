@@ -0,0 +1,208 @@
+package aleo_utils
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// Logger is a structured logging hook used for wrapper lifecycle events and for
+// guest log messages received over the host_log_string import. level is a
+// short free-form string such as "info" or "error"; kv is an optional sequence
+// of alternating key/value pairs, following the convention used by log/slog.
+type Logger func(level, msg string, kv ...any)
+
+// defaultLogger is used when no Logger is supplied via WithLogger, preserving
+// the historical behavior of logging through the standard library logger.
+func defaultLogger(level, msg string, kv ...any) {
+	args := append([]any{msg}, kv...)
+	log.Println(args...)
+}
+
+// Option configures a Wrapper created via NewWrapperWithOptions.
+type Option func(*wrapperOptions)
+
+// Engine selects the wazero execution engine used by the runtime.
+type Engine int
+
+const (
+	// EngineCompiler ahead-of-time compiles the WASM module to native code. It is
+	// the default and fastest engine, but is unavailable on some platforms (e.g.
+	// 32-bit, some BSDs, iOS).
+	EngineCompiler Engine = iota
+	// EngineInterpreter runs the WASM module in a portable interpreter. Use this
+	// on platforms the compiler engine doesn't support.
+	EngineInterpreter
+)
+
+type wrapperOptions struct {
+	cacheDir      string
+	readOnlyCache bool
+	engine        Engine
+	coreFeatures  api.CoreFeatures
+	logger        Logger
+	hostFunctions map[string]any
+}
+
+// WithEngine selects the wazero engine used to run the WASM module. Defaults to
+// EngineCompiler, matching NewWrapper's historical behavior.
+func WithEngine(e Engine) Option {
+	return func(o *wrapperOptions) {
+		o.engine = e
+	}
+}
+
+// WithCoreFeatures pins the WASM core features (e.g. api.CoreFeaturesV1,
+// api.CoreFeaturesV2) the runtime accepts, overriding wazero's engine default.
+// Testers can use this to pin reproducible behavior across wazero releases.
+func WithCoreFeatures(features api.CoreFeatures) Option {
+	return func(o *wrapperOptions) {
+		o.coreFeatures = features
+	}
+}
+
+// WithCompilationCache enables an on-disk cache of the compiled aleo_utils.wasm
+// module at dir, so subsequent process restarts can skip the slow compile step.
+// If dir is unusable, the error is logged through the Logger, the cache is
+// disabled, and compilation falls back to the in-memory (non-persisted) path.
+func WithCompilationCache(dir string) Option {
+	return func(o *wrapperOptions) {
+		o.cacheDir = dir
+	}
+}
+
+// WithReadOnlyCache, when true, prevents NewWrapperWithOptions from writing newly
+// compiled artifacts back into the directory passed to WithCompilationCache. This
+// lets a shipped image bundle a pre-warmed cache directory that every instance
+// reads from without mutating the image's contents.
+func WithReadOnlyCache(readOnly bool) Option {
+	return func(o *wrapperOptions) {
+		o.readOnlyCache = readOnly
+	}
+}
+
+// WithLogger routes wrapper lifecycle events and guest log messages through
+// logger instead of the standard library logger. Pass nil to restore the
+// default.
+func WithLogger(logger Logger) Option {
+	return func(o *wrapperOptions) {
+		o.logger = logger
+	}
+}
+
+// WithHostFunctions registers additional host functions into the "env" module
+// alongside host_log_string, keyed by their exported name. This lets callers
+// add imports such as a metrics counter or a panic reporter without forking
+// the package. Values must be function types accepted by wazero's
+// HostFunctionBuilder.WithFunc.
+func WithHostFunctions(fns map[string]any) Option {
+	return func(o *wrapperOptions) {
+		o.hostFunctions = fns
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildCompilationCache resolves the requested cache directory into a
+// wazero.CompilationCache, tolerating a missing or unreadable directory by
+// logging and returning a nil cache so the caller falls back to no caching.
+// When a read-only cache is used, tempDir is the scratch directory the cache
+// was copied into; the caller is responsible for removing it once the cache
+// is no longer in use.
+func buildCompilationCache(o *wrapperOptions, logger Logger) (cache wazero.CompilationCache, tempDir string) {
+	if o.cacheDir == "" {
+		return nil, ""
+	}
+
+	dir := o.cacheDir
+	if o.readOnlyCache {
+		tmp, err := copyToTempDir(dir)
+		if err != nil {
+			logger("error", "compilation cache: read-only dir unusable, disabling cache", "dir", dir, "err", err)
+			return nil, ""
+		}
+		dir = tmp
+		tempDir = tmp
+	}
+
+	cache, err := wazero.NewCompilationCacheWithDir(dir)
+	if err != nil {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+			tempDir = ""
+		}
+		logger("error", "compilation cache: dir unusable, disabling cache", "dir", dir, "err", err)
+		return nil, ""
+	}
+
+	return cache, tempDir
+}
+
+// copyToTempDir recursively copies the contents of src (including the
+// wazero-<version>-<arch>-<os> subdirectory wazero stores compiled artifacts
+// in) into a fresh temporary directory and returns its path, so writes
+// against the copy never affect src.
+func copyToTempDir(src string) (string, error) {
+	if _, err := os.Stat(src); err != nil {
+		return "", err
+	}
+
+	dst, err := os.MkdirTemp("", "aleo-utils-cache-*")
+	if err != nil {
+		return "", err
+	}
+
+	err = filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		return copyFile(path, target)
+	})
+	if err != nil {
+		_ = os.RemoveAll(dst)
+		return "", err
+	}
+
+	return dst, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
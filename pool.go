@@ -0,0 +1,143 @@
+package aleo_utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is returned by SessionPool methods once the pool has been closed.
+var ErrPoolClosed = errors.New("session pool is closed")
+
+// healthChecker is implemented by Session values that can report whether their
+// underlying wasm module instance is still usable.
+type healthChecker interface {
+	healthy() bool
+}
+
+func sessionHealthy(session Session) bool {
+	if hc, ok := session.(healthChecker); ok {
+		return hc.healthy()
+	}
+	return true
+}
+
+// SessionPool is a goroutine-safe, bounded pool of pre-instantiated Sessions.
+// Construct one with Wrapper.NewPool to share a fixed number of wasm module
+// instances across goroutines, instead of every goroutine managing its own
+// Session.
+type SessionPool struct {
+	newSession func() (Session, error)
+	logger     Logger
+
+	sessions chan Session
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// NewPool pre-instantiates size wasm module instances and returns a SessionPool
+// that hands them out via Acquire.
+func (s *aleoWrapper) NewPool(size int) (*SessionPool, error) {
+	if size <= 0 {
+		return nil, errors.New("pool size must be positive")
+	}
+
+	pool := &SessionPool{
+		newSession: func() (Session, error) { return s.NewSession() },
+		logger:     s.logger,
+		sessions:   make(chan Session, size),
+		closed:     make(chan struct{}),
+	}
+
+	for i := 0; i < size; i++ {
+		session, err := pool.newSession()
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to pre-instantiate session %d/%d: %w", i+1, size, err)
+		}
+		pool.sessions <- session
+	}
+
+	return pool, nil
+}
+
+// Acquire waits for an available Session and returns it along with a release
+// function the caller must invoke once done with it. If ctx is done before a
+// session becomes available, Acquire returns ctx.Err(). Acquire returns
+// ErrPoolClosed once the pool has been closed.
+func (p *SessionPool) Acquire(ctx context.Context) (Session, func(), error) {
+	select {
+	case <-p.closed:
+		return nil, nil, ErrPoolClosed
+	default:
+	}
+
+	select {
+	case session, ok := <-p.sessions:
+		if !ok {
+			return nil, nil, ErrPoolClosed
+		}
+		var releaseOnce sync.Once
+		release := func() {
+			releaseOnce.Do(func() {
+				p.release(session)
+			})
+		}
+		return session, release, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-p.closed:
+		return nil, nil, ErrPoolClosed
+	}
+}
+
+// release returns session to the pool. If the session failed its health check
+// (its wasm module was closed), it is discarded and replaced with a freshly
+// instantiated one so the pool's capacity doesn't shrink. If instantiating the
+// replacement fails, the error is logged and the pool keeps one session fewer.
+func (p *SessionPool) release(session Session) {
+	select {
+	case <-p.closed:
+		session.Close()
+		return
+	default:
+	}
+
+	if !sessionHealthy(session) {
+		session.Close()
+		replacement, err := p.newSession()
+		if err != nil {
+			logger := p.logger
+			if logger == nil {
+				logger = defaultLogger
+			}
+			logger("error", "session pool: failed to replace unhealthy session", "err", err)
+			return
+		}
+		session = replacement
+	}
+
+	select {
+	case p.sessions <- session:
+	case <-p.closed:
+		session.Close()
+	}
+}
+
+// Close drains and closes all sessions currently held by the pool, and causes
+// any future Acquire calls to return ErrPoolClosed. Sessions already checked
+// out are closed by their own release function once returned.
+func (p *SessionPool) Close() {
+	p.once.Do(func() {
+		close(p.closed)
+		for {
+			select {
+			case session := <-p.sessions:
+				session.Close()
+			default:
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package aleo_utils
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSealingUnavailable is returned by SealPrivateKey, UnsealPrivateKey, and
+// NewSealedPrivateKey on builds that aren't tagged with `ego`, so the API
+// surface stays stable whether or not the SGX sealing backend is compiled in.
+var ErrSealingUnavailable = errors.New("private key sealing requires a build tagged with ego")
+
+// SealPolicy selects which SGX key a sealed private key is bound to.
+type SealPolicy int
+
+const (
+	// SealPolicyUniqueEnclave binds the seal to this exact enclave's
+	// MRENCLAVE measurement: only the same enclave binary can unseal it.
+	SealPolicyUniqueEnclave SealPolicy = iota
+	// SealPolicyProductSigner binds the seal to the enclave signer's
+	// MRSIGNER, so any enclave signed by the same key (e.g. a later release
+	// of this binary) can unseal it.
+	SealPolicyProductSigner
+)
+
+// NewSealedPrivateKey generates a new private key inside WASM, immediately
+// seals it under policy, and returns the sealed blob plus the derived
+// address. The plaintext key is wiped from memory before returning and never
+// leaves the enclave.
+func (s *aleoWrapperSession) NewSealedPrivateKey(policy SealPolicy) (sealed []byte, address string, err error) {
+	return s.NewSealedPrivateKeyContext(context.Background(), policy)
+}
+
+// NewSealedPrivateKeyContext is NewSealedPrivateKey, but passes ctx into the underlying calls.
+func (s *aleoWrapperSession) NewSealedPrivateKeyContext(ctx context.Context, policy SealPolicy) (sealed []byte, address string, err error) {
+	key, address, err := s.NewPrivateKeyContext(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer zeroize(key)
+
+	sealed, err = s.SealPrivateKeyContext(ctx, key, policy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sealed, address, nil
+}
+
+// zeroize overwrites b in place, best-effort, so a plaintext private key
+// doesn't linger in memory after it's no longer needed.
+func zeroize(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,48 @@
+//go:build ego
+
+package aleo_utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgelesssys/ego/ecrypto"
+)
+
+// SealPrivateKey seals key for storage outside the enclave, binding it
+// according to policy.
+func (s *aleoWrapperSession) SealPrivateKey(key []byte, policy SealPolicy) (sealed []byte, err error) {
+	return s.SealPrivateKeyContext(context.Background(), key, policy)
+}
+
+// SealPrivateKeyContext is SealPrivateKey, but accepts ctx for consistency with the rest of Session.
+// Sealing is a local enclave operation and does not block on I/O, so ctx is not consulted.
+func (s *aleoWrapperSession) SealPrivateKeyContext(_ context.Context, key []byte, policy SealPolicy) (sealed []byte, err error) {
+	switch policy {
+	case SealPolicyUniqueEnclave:
+		sealed, err = ecrypto.SealWithUniqueKey(key, nil)
+	case SealPolicyProductSigner:
+		sealed, err = ecrypto.SealWithProductKey(key, nil)
+	default:
+		return nil, fmt.Errorf("unknown seal policy: %v", policy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal private key: %w", err)
+	}
+	return sealed, nil
+}
+
+// UnsealPrivateKey reverses SealPrivateKey, returning the plaintext private key.
+func (s *aleoWrapperSession) UnsealPrivateKey(sealed []byte) (key []byte, err error) {
+	return s.UnsealPrivateKeyContext(context.Background(), sealed)
+}
+
+// UnsealPrivateKeyContext is UnsealPrivateKey, but accepts ctx for consistency with the rest of Session.
+// Unsealing is a local enclave operation and does not block on I/O, so ctx is not consulted.
+func (s *aleoWrapperSession) UnsealPrivateKeyContext(_ context.Context, sealed []byte) (key []byte, err error) {
+	key, err = ecrypto.Unseal(sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal private key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,29 @@
+//go:build !ego
+
+package aleo_utils
+
+import "context"
+
+// SealPrivateKey is unavailable on this build; compile with the ego build tag
+// (and the ego runtime) to enable SGX sealing.
+func (s *aleoWrapperSession) SealPrivateKey(key []byte, policy SealPolicy) (sealed []byte, err error) {
+	return nil, ErrSealingUnavailable
+}
+
+// SealPrivateKeyContext is unavailable on this build; compile with the ego build tag
+// (and the ego runtime) to enable SGX sealing.
+func (s *aleoWrapperSession) SealPrivateKeyContext(ctx context.Context, key []byte, policy SealPolicy) (sealed []byte, err error) {
+	return nil, ErrSealingUnavailable
+}
+
+// UnsealPrivateKey is unavailable on this build; compile with the ego build tag
+// (and the ego runtime) to enable SGX sealing.
+func (s *aleoWrapperSession) UnsealPrivateKey(sealed []byte) (key []byte, err error) {
+	return nil, ErrSealingUnavailable
+}
+
+// UnsealPrivateKeyContext is unavailable on this build; compile with the ego build tag
+// (and the ego runtime) to enable SGX sealing.
+func (s *aleoWrapperSession) UnsealPrivateKeyContext(ctx context.Context, sealed []byte) (key []byte, err error) {
+	return nil, ErrSealingUnavailable
+}
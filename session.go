@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/tetratelabs/wazero/api"
@@ -13,19 +12,67 @@ import (
 var ErrNoModule = errors.New("session module is closed")
 
 // Provides access to wrapper functionality. A session is not goroutine safe so
-// you need to create a new one for every goroutine
+// you need to create a new one for every goroutine.
+//
+// Every operation has a context-taking variant (e.g. SignContext) that passes
+// ctx into the underlying wasm call, letting a caller enforce a timeout or
+// cancel a hung call. The plain methods below delegate to those using
+// context.Background(), preserving the original API.
 type Session interface {
 	// NewPrivateKey returns a newly generated private key as a byte slice and the
 	// corresponding address. The caller is responsible for zeroizing the returned
 	// slice when it is no longer needed (see ZeroizePrivateKey).
 	NewPrivateKey() (key []byte, address string, err error)
+	NewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error)
 	FormatMessage(message []byte, targetChunks int) (formattedMessage []byte, err error)
+	FormatMessageContext(ctx context.Context, message []byte, targetChunks int) (formattedMessage []byte, err error)
 	RecoverMessage(formattedMessage []byte) (message []byte, err error)
+	RecoverMessageContext(ctx context.Context, formattedMessage []byte) (message []byte, err error)
 	HashMessageToString(message []byte) (hash string, err error)
+	HashMessageToStringContext(ctx context.Context, message []byte) (hash string, err error)
 	HashMessage(message []byte) (hash []byte, err error)
+	HashMessageContext(ctx context.Context, message []byte) (hash []byte, err error)
 	// Sign creates an Aleo-compatible Schnorr signature. The private key is not
 	// copied as a string and is wiped from WASM memory immediately after use.
 	Sign(key []byte, message []byte) (signature string, err error)
+	SignContext(ctx context.Context, key []byte, message []byte) (signature string, err error)
+
+	// SignBatch signs every message in messages with key, amortizing wasm memory
+	// allocation across the batch instead of round-tripping alloc/dealloc per
+	// message. It uses the module's sign_many export for a single boundary
+	// crossing when Capabilities().SupportsBatch is true, and otherwise falls
+	// back to one sign call per message while reusing a shared scratch buffer
+	// and the private-key region across the batch. It always signs inside the
+	// session's own WASM module, not through the Signer set by WithSigner, so
+	// key must be the raw PRIVATE_KEY_SIZE-byte private key.
+	SignBatch(key []byte, messages [][]byte) (signatures []string, err error)
+	SignBatchContext(ctx context.Context, key []byte, messages [][]byte) (signatures []string, err error)
+	// HashMessageBatch hashes every message in messages, reusing a shared scratch
+	// buffer across the batch instead of allocating one per message.
+	HashMessageBatch(messages [][]byte) (hashes [][]byte, err error)
+	HashMessageBatchContext(ctx context.Context, messages [][]byte) (hashes [][]byte, err error)
+
+	// SealPrivateKey seals key for storage outside the enclave, binding it
+	// according to policy (see SealPolicy). Only available on builds tagged
+	// with `ego`; other builds return ErrSealingUnavailable.
+	SealPrivateKey(key []byte, policy SealPolicy) (sealed []byte, err error)
+	SealPrivateKeyContext(ctx context.Context, key []byte, policy SealPolicy) (sealed []byte, err error)
+	// UnsealPrivateKey reverses SealPrivateKey, returning the plaintext
+	// private key. Only available on builds tagged with `ego`; other builds
+	// return ErrSealingUnavailable.
+	UnsealPrivateKey(sealed []byte) (key []byte, err error)
+	UnsealPrivateKeyContext(ctx context.Context, sealed []byte) (key []byte, err error)
+	// NewSealedPrivateKey generates a new private key and immediately seals
+	// it under policy, returning the sealed blob and the derived address —
+	// the plaintext key is wiped before returning and never leaves the
+	// enclave. Only available on builds tagged with `ego`; other builds
+	// return ErrSealingUnavailable.
+	NewSealedPrivateKey(policy SealPolicy) (sealed []byte, address string, err error)
+	NewSealedPrivateKeyContext(ctx context.Context, policy SealPolicy) (sealed []byte, address string, err error)
+
+	// Capabilities returns what the loaded WASM module was negotiated to
+	// support at session creation. See Capabilities for details.
+	Capabilities() Capabilities
 
 	Close()
 }
@@ -36,7 +83,17 @@ type aleoWrapperSession struct {
 
 	// unique wasm module for this session
 	mod api.Module
-	ctx context.Context
+
+	// logger receives this session's lifecycle/error events, so WithLogger
+	// fully silences/redirects the package instead of just guest log messages.
+	logger Logger
+
+	// signer performs private-key operations (NewPrivateKey, Sign). Defaults to
+	// a wasmSigner bound to this session, but can be overridden via WithSigner.
+	signer Signer
+
+	// capabilities is what was negotiated with the wasm module at construction.
+	capabilities Capabilities
 
 	newPrivateKey    api.Function
 	getAddress       api.Function
@@ -47,6 +104,22 @@ type aleoWrapperSession struct {
 	hashMessageBytes api.Function
 	formatMessage    api.Function
 	recoverMessage   api.Function
+
+	// signMany is the optional batch-signing export, nil when the module
+	// doesn't export sign_many. SignBatch uses it only when
+	// Capabilities().SupportsBatch is also true.
+	signMany api.Function
+}
+
+// logf routes an internal lifecycle/error event through the session's Logger,
+// falling back to defaultLogger if none was supplied (e.g. an
+// aleoWrapperSession constructed directly rather than via NewSession).
+func (s *aleoWrapperSession) logf(level, msg string, kv ...any) {
+	logger := s.logger
+	if logger == nil {
+		logger = defaultLogger
+	}
+	logger(level, msg, kv...)
 }
 
 func (s *aleoWrapperSession) Close() {
@@ -55,10 +128,24 @@ func (s *aleoWrapperSession) Close() {
 	}
 }
 
+// Capabilities returns what the loaded WASM module was negotiated to support
+// at session creation.
+func (s *aleoWrapperSession) Capabilities() Capabilities {
+	return s.capabilities
+}
+
+// healthy reports whether the session's underlying wasm module instance is
+// still open, so a SessionPool can discard a closed session instead of handing
+// it back out. Only IsClosed is checked: a call that returned an error without
+// closing the module leaves the session reported as healthy.
+func (s *aleoWrapperSession) healthy() bool {
+	return s.mod != nil && !s.mod.IsClosed()
+}
+
 // Helper function to allocate memory safely with actual capacity tracking
-func (s *aleoWrapperSession) allocateSafe(size uint64) (ptr uint64, actualCapacity uint64, err error) {
+func (s *aleoWrapperSession) allocateSafe(ctx context.Context, size uint64) (ptr uint64, actualCapacity uint64, err error) {
 	// New alloc returns a raw pointer (to data) and stores capacity in an 8-byte header.
-	result, err := s.allocate.Call(s.ctx, size)
+	result, err := s.allocate.Call(ctx, size)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -67,8 +154,8 @@ func (s *aleoWrapperSession) allocateSafe(size uint64) (ptr uint64, actualCapaci
 }
 
 // Helper function to deallocate memory safely with exact capacity
-func (s *aleoWrapperSession) deallocateSafe(ptr uint64, actualCapacity uint64) error {
-	_, err := s.deallocate.Call(s.ctx, ptr, 0)
+func (s *aleoWrapperSession) deallocateSafe(ctx context.Context, ptr uint64, actualCapacity uint64) error {
+	_, err := s.deallocate.Call(ctx, ptr, 0)
 	return err
 }
 
@@ -78,6 +165,17 @@ func decodeLenPtr(encoded uint64) (ptr uint32, length uint32) {
 
 // NewPrivateKey generates a new Aleo private key, returns it's string representation and the address derived from that private key.
 func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err error) {
+	return s.NewPrivateKeyContext(context.Background())
+}
+
+// NewPrivateKeyContext is NewPrivateKey, but passes ctx into the underlying wasm calls.
+// It delegates to the session's Signer (see WithSigner).
+func (s *aleoWrapperSession) NewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error) {
+	return s.signer.NewPrivateKeyContext(ctx)
+}
+
+// wasmNewPrivateKeyContext is the wasmSigner's implementation of NewPrivateKeyContext.
+func (s *aleoWrapperSession) wasmNewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return nil, "", ErrNoModule
 	}
@@ -100,9 +198,9 @@ func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err er
 
 	// generate new private key
 	var privKeyPtr []uint64
-	privKeyPtr, err = s.newPrivateKey.Call(s.ctx)
+	privKeyPtr, err = s.newPrivateKey.Call(ctx)
 	if err != nil {
-		log.Println("new_private_key error:", err)
+		s.logf("error", "new_private_key error", "err", err)
 		return
 	}
 	if len(privKeyPtr) == 0 {
@@ -113,15 +211,15 @@ func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err er
 		return nil, "", errors.New("failed to create new private key: invalid pointer")
 	}
 	if keyLen != PRIVATE_KEY_SIZE {
-		log.Printf("unexpected private key length %d (expected %d)", keyLen, PRIVATE_KEY_SIZE)
+		s.logf("error", "unexpected private key length", "got", keyLen, "expected", PRIVATE_KEY_SIZE)
 	}
 	defer func(ptr uint32, length uint32) {
 		// Zero out the memory first
 		zero := make([]byte, int(length))
 		_ = s.mod.Memory().Write(ptr, zero)
 		// Then deallocate
-		if err := s.deallocateSafe(uint64(ptr), 0); err != nil {
-			log.Printf("Failed to deallocate private key memory: %v", err)
+		if err := s.deallocateSafe(ctx, uint64(ptr), 0); err != nil {
+			s.logf("error", "failed to deallocate private key memory", "err", err)
 		}
 	}(keyPtr, keyLen)
 
@@ -134,9 +232,9 @@ func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err er
 	copy(key, privKeyWasm)
 
 	// get public address from the private key, reuse the returned value from private key generation
-	addressPtr, err := s.getAddress.Call(s.ctx, uint64(keyPtr), uint64(keyLen))
+	addressPtr, err := s.getAddress.Call(ctx, uint64(keyPtr), uint64(keyLen))
 	if err != nil {
-		log.Println("get_address error:", err)
+		s.logf("error", "get_address error", "err", err)
 		return nil, "", errors.New("failed to get address from the generated private key")
 	}
 	if len(addressPtr) == 0 {
@@ -147,11 +245,11 @@ func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err er
 		return nil, "", errors.New("internal error when getting address from the generated private key")
 	}
 	if addrLen != ADDRESS_SIZE {
-		log.Printf("unexpected address length %d (expected %d)", addrLen, ADDRESS_SIZE)
+		s.logf("error", "unexpected address length", "got", addrLen, "expected", ADDRESS_SIZE)
 	}
 	defer func(ptr uint32) {
-		if err := s.deallocateSafe(uint64(ptr), 0); err != nil {
-			log.Printf("Failed to deallocate address memory: %v", err)
+		if err := s.deallocateSafe(ctx, uint64(ptr), 0); err != nil {
+			s.logf("error", "failed to deallocate address memory", "err", err)
 		}
 	}(addrPtr)
 
@@ -176,6 +274,11 @@ func (s *aleoWrapperSession) NewPrivateKey() (key []byte, address string, err er
 // FormatMessage formats a byte array as a Leo struct of up to 32 structs of 32 u128 numbers. The returned value
 // is a string representation of that struct, as bytes.
 func (s *aleoWrapperSession) FormatMessage(message []byte, targetChunks int) (formattedMessage []byte, err error) {
+	return s.FormatMessageContext(context.Background(), message, targetChunks)
+}
+
+// FormatMessageContext is FormatMessage, but passes ctx into the underlying wasm call.
+func (s *aleoWrapperSession) FormatMessageContext(ctx context.Context, message []byte, targetChunks int) (formattedMessage []byte, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return nil, ErrNoModule
 	}
@@ -195,27 +298,30 @@ func (s *aleoWrapperSession) FormatMessage(message []byte, targetChunks int) (fo
 		}
 	}()
 
-	if targetChunks < 1 || targetChunks > MAX_FORMAT_MESSAGE_CHUNKS {
-		return nil, errors.New("target number of chunks must be between 1 and 32")
+	maxChunks := s.capabilities.MaxFormatChunks
+	blockSize := s.capabilities.BlockSize
+
+	if targetChunks < 1 || targetChunks > maxChunks {
+		return nil, fmt.Errorf("target number of chunks must be between 1 and %d", maxChunks)
 	}
 
-	if len(message) > targetChunks*MESSAGE_FORMAT_BLOCK_SIZE {
-		return nil, fmt.Errorf("target formatted message length must be at most %d (%d chunks)", targetChunks*MESSAGE_FORMAT_BLOCK_SIZE, targetChunks)
+	if len(message) > targetChunks*blockSize {
+		return nil, fmt.Errorf("target formatted message length must be at most %d (%d chunks)", targetChunks*blockSize, targetChunks)
 	}
 
 	msgLen := uint64(len(message))
 
 	// FIXED: Use safe allocation that tracks actual capacity
-	messagePtr, _, err := s.allocateSafe(msgLen)
+	messagePtr, _, err := s.allocateSafe(ctx, msgLen)
 	if err != nil {
-		log.Println("message allocate error:", err)
+		s.logf("error", "message allocate error", "err", err)
 		return nil, errors.New("failed to allocate memory for message")
 	}
 
 	// Deallocate (capacity stored in header, second arg ignored)
 	defer func() {
-		if err := s.deallocateSafe(messagePtr, 0); err != nil {
-			log.Printf("Failed to deallocate message memory: %v", err)
+		if err := s.deallocateSafe(ctx, messagePtr, 0); err != nil {
+			s.logf("error", "failed to deallocate message memory", "err", err)
 		}
 	}()
 
@@ -226,9 +332,9 @@ func (s *aleoWrapperSession) FormatMessage(message []byte, targetChunks int) (fo
 	}
 
 	// call format message with the pointer to the message
-	formatResult, err := s.formatMessage.Call(s.ctx, messagePtr, msgLen, uint64(targetChunks))
+	formatResult, err := s.formatMessage.Call(ctx, messagePtr, msgLen, uint64(targetChunks))
 	if err != nil {
-		log.Println("string format error:", err)
+		s.logf("error", "string format error", "err", err)
 		return nil, errors.New("failed to format message")
 	}
 	if len(formatResult) == 0 {
@@ -251,7 +357,7 @@ func (s *aleoWrapperSession) FormatMessage(message []byte, targetChunks int) (fo
 	}
 	// FIXED: This output is allocated by Rust using forget_buf_ptr_len,
 	// so we need to deallocate using the actual buffer size, not strLen
-	defer s.deallocate.Call(s.ctx, uint64(strPtr), uint64(strLen))
+	defer s.deallocate.Call(ctx, uint64(strPtr), uint64(strLen))
 
 	// since memory read returns a slice of wasm memory buffer, it needs to be copied
 	// to avoid our returned slice being wiped when wasm memory is wiped
@@ -265,6 +371,11 @@ func (s *aleoWrapperSession) FormatMessage(message []byte, targetChunks int) (fo
 
 // Recovers the original byte message from a formatted message string that was created using FormatMessage
 func (s *aleoWrapperSession) RecoverMessage(formattedMessage []byte) (message []byte, err error) {
+	return s.RecoverMessageContext(context.Background(), formattedMessage)
+}
+
+// RecoverMessageContext is RecoverMessage, but passes ctx into the underlying wasm call.
+func (s *aleoWrapperSession) RecoverMessageContext(ctx context.Context, formattedMessage []byte) (message []byte, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return nil, ErrNoModule
 	}
@@ -287,16 +398,16 @@ func (s *aleoWrapperSession) RecoverMessage(formattedMessage []byte) (message []
 	formattedMsgLen := uint64(len(formattedMessage))
 
 	// FIXED: Use safe allocation that tracks actual capacity
-	formattedMessagePtr, _, err := s.allocateSafe(formattedMsgLen)
+	formattedMessagePtr, _, err := s.allocateSafe(ctx, formattedMsgLen)
 	if err != nil {
-		log.Println("message allocate error:", err)
+		s.logf("error", "message allocate error", "err", err)
 		return nil, errors.New("failed to allocate memory for message")
 	}
 
 	// Deallocate (capacity stored in header, second arg ignored)
 	defer func() {
-		if err := s.deallocateSafe(formattedMessagePtr, 0); err != nil {
-			log.Printf("Failed to deallocate formatted message memory: %v", err)
+		if err := s.deallocateSafe(ctx, formattedMessagePtr, 0); err != nil {
+			s.logf("error", "failed to deallocate formatted message memory", "err", err)
 		}
 	}()
 
@@ -307,9 +418,9 @@ func (s *aleoWrapperSession) RecoverMessage(formattedMessage []byte) (message []
 	}
 
 	// call recover message with the pointer to the message
-	recoverResult, err := s.recoverMessage.Call(s.ctx, formattedMessagePtr, formattedMsgLen)
+	recoverResult, err := s.recoverMessage.Call(ctx, formattedMessagePtr, formattedMsgLen)
 	if err != nil {
-		log.Println("string recover error:", err)
+		s.logf("error", "string recover error", "err", err)
 		return nil, errors.New("failed to recover message")
 	}
 	if len(recoverResult) == 0 {
@@ -330,7 +441,7 @@ func (s *aleoWrapperSession) RecoverMessage(formattedMessage []byte) (message []
 	if !ok {
 		return nil, errors.New("failed to convert message to a field")
 	}
-	defer s.deallocate.Call(s.ctx, uint64(bugPtr), uint64(bufLen))
+	defer s.deallocate.Call(ctx, uint64(bugPtr), uint64(bufLen))
 
 	// since memory read returns a slice of wasm memory buffer, it needs to be copied
 	// to avoid our returned slice being wiped when wasm memory is wiped
@@ -345,6 +456,11 @@ func (s *aleoWrapperSession) RecoverMessage(formattedMessage []byte) (message []
 //
 // Use this function if you need a hash as a literal, for example for using it in a contract.
 func (s *aleoWrapperSession) HashMessageToString(message []byte) (hash string, err error) {
+	return s.HashMessageToStringContext(context.Background(), message)
+}
+
+// HashMessageToStringContext is HashMessageToString, but passes ctx into the underlying wasm call.
+func (s *aleoWrapperSession) HashMessageToStringContext(ctx context.Context, message []byte) (hash string, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return "", ErrNoModule
 	}
@@ -367,16 +483,16 @@ func (s *aleoWrapperSession) HashMessageToString(message []byte) (hash string, e
 	msgLen := uint64(len(message))
 
 	// FIXED: Use safe allocation that tracks actual capacity
-	messagePtr, _, err := s.allocateSafe(msgLen)
+	messagePtr, _, err := s.allocateSafe(ctx, msgLen)
 	if err != nil {
-		log.Println("message allocate error:", err)
+		s.logf("error", "message allocate error", "err", err)
 		return "", errors.New("failed to allocate memory for message")
 	}
 
 	// Deallocate (capacity stored in header, second arg ignored)
 	defer func() {
-		if err := s.deallocateSafe(messagePtr, 0); err != nil {
-			log.Printf("Failed to deallocate hash message memory: %v", err)
+		if err := s.deallocateSafe(ctx, messagePtr, 0); err != nil {
+			s.logf("error", "failed to deallocate hash message memory", "err", err)
 		}
 	}()
 
@@ -387,9 +503,9 @@ func (s *aleoWrapperSession) HashMessageToString(message []byte) (hash string, e
 	}
 
 	// call the hash function and pass the pointer to the message
-	hashResult, err := s.hashMessage.Call(s.ctx, messagePtr, msgLen)
+	hashResult, err := s.hashMessage.Call(ctx, messagePtr, msgLen)
 	if err != nil {
-		log.Println("hash message error:", err)
+		s.logf("error", "hash message error", "err", err)
 		return "", errors.New("failed to hash message to a string representation")
 	}
 	if len(hashResult) == 0 {
@@ -410,7 +526,7 @@ func (s *aleoWrapperSession) HashMessageToString(message []byte) (hash string, e
 	if !ok {
 		return "", errors.New("failed to convert message to a field")
 	}
-	defer s.deallocate.Call(s.ctx, uint64(hashPtr), uint64(hashLen))
+	defer s.deallocate.Call(ctx, uint64(hashPtr), uint64(hashLen))
 
 	// since memory read returns a slice of wasm memory buffer, it needs to be copied
 	// to avoid our returned slice being wiped when wasm memory is wiped.
@@ -423,6 +539,11 @@ func (s *aleoWrapperSession) HashMessageToString(message []byte) (hash string, e
 // HashMessage hashes a message using Poseidon8 Leo function, and returns a little-endian
 // byte representation of a resulting U128.
 func (s *aleoWrapperSession) HashMessage(message []byte) (hash []byte, err error) {
+	return s.HashMessageContext(context.Background(), message)
+}
+
+// HashMessageContext is HashMessage, but passes ctx into the underlying wasm call.
+func (s *aleoWrapperSession) HashMessageContext(ctx context.Context, message []byte) (hash []byte, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return nil, ErrNoModule
 	}
@@ -445,16 +566,16 @@ func (s *aleoWrapperSession) HashMessage(message []byte) (hash []byte, err error
 	msgLen := uint64(len(message))
 
 	// FIXED: Use safe allocation that tracks actual capacity
-	messagePtr, _, err := s.allocateSafe(msgLen)
+	messagePtr, _, err := s.allocateSafe(ctx, msgLen)
 	if err != nil {
-		log.Println("message allocate error:", err)
+		s.logf("error", "message allocate error", "err", err)
 		return nil, errors.New("failed to allocate memory for message")
 	}
 
 	// Deallocate (capacity stored in header, second arg ignored)
 	defer func() {
-		if err := s.deallocateSafe(messagePtr, 0); err != nil {
-			log.Printf("Failed to deallocate hash message bytes memory: %v", err)
+		if err := s.deallocateSafe(ctx, messagePtr, 0); err != nil {
+			s.logf("error", "failed to deallocate hash message bytes memory", "err", err)
 		}
 	}()
 
@@ -465,9 +586,9 @@ func (s *aleoWrapperSession) HashMessage(message []byte) (hash []byte, err error
 	}
 
 	// pass message to the hash function
-	hashResult, err := s.hashMessageBytes.Call(s.ctx, messagePtr, msgLen)
+	hashResult, err := s.hashMessageBytes.Call(ctx, messagePtr, msgLen)
 	if err != nil {
-		log.Println("hash message bytes error:", err)
+		s.logf("error", "hash message bytes error", "err", err)
 		return nil, errors.New("failed to hash message")
 	}
 	if len(hashResult) == 0 {
@@ -488,7 +609,7 @@ func (s *aleoWrapperSession) HashMessage(message []byte) (hash []byte, err error
 	if !ok {
 		return nil, errors.New("failed to convert message to a field")
 	}
-	defer s.deallocate.Call(s.ctx, uint64(hashPtr), uint64(hashLen))
+	defer s.deallocate.Call(ctx, uint64(hashPtr), uint64(hashLen))
 
 	// since memory read returns a slice of wasm memory buffer, it needs to be copied
 	// to avoid our returned slice being wiped when wasm memory is wiped
@@ -501,6 +622,18 @@ func (s *aleoWrapperSession) HashMessage(message []byte) (hash []byte, err error
 // Creates an Aleo-compatible Schnorr signature, returns the signature's string representation.
 // The message must be a string or little-endian byte representation of a Leo U128.
 func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string, err error) {
+	return s.SignContext(context.Background(), key, message)
+}
+
+// SignContext is Sign, but passes ctx into the underlying wasm call, so a caller can
+// enforce a timeout or deadline on signing under load, or abort cleanly on shutdown.
+// It delegates to the session's Signer (see WithSigner).
+func (s *aleoWrapperSession) SignContext(ctx context.Context, key []byte, message []byte) (signature string, err error) {
+	return s.signer.SignContext(ctx, key, message)
+}
+
+// wasmSignContext is the wasmSigner's implementation of SignContext.
+func (s *aleoWrapperSession) wasmSignContext(ctx context.Context, key []byte, message []byte) (signature string, err error) {
 	if s.mod == nil || s.mod.IsClosed() {
 		return "", ErrNoModule
 	}
@@ -526,15 +659,15 @@ func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string,
 
 	// allocate memory for the message to pass to the signing function using safe allocator
 	msgLen := uint64(len(message))
-	messagePtr, _, err := s.allocateSafe(msgLen)
+	messagePtr, _, err := s.allocateSafe(ctx, msgLen)
 	if err != nil {
-		log.Println("message allocate error:", err)
+		s.logf("error", "message allocate error", "err", err)
 		return "", errors.New("failed to allocate memory for message")
 	}
 
 	defer func() {
-		if err := s.deallocateSafe(messagePtr, 0); err != nil { // second arg ignored
-			log.Printf("Failed to deallocate message memory in Sign: %v", err)
+		if err := s.deallocateSafe(ctx, messagePtr, 0); err != nil { // second arg ignored
+			s.logf("error", "failed to deallocate message memory in Sign", "err", err)
 		}
 	}()
 
@@ -545,15 +678,15 @@ func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string,
 	}
 
 	// allocate memory for private key to pass to the signing function using safe allocator
-	privateKeyPtr, _, err := s.allocateSafe(PRIVATE_KEY_SIZE)
+	privateKeyPtr, _, err := s.allocateSafe(ctx, PRIVATE_KEY_SIZE)
 	if err != nil {
-		log.Println("private key allocate error:", err)
+		s.logf("error", "private key allocate error", "err", err)
 		return "", errors.New("failed to allocate memory for private key")
 	}
 
 	defer func() {
-		if err := s.deallocateSafe(privateKeyPtr, 0); err != nil {
-			log.Printf("Failed to deallocate private key memory in Sign: %v", err)
+		if err := s.deallocateSafe(ctx, privateKeyPtr, 0); err != nil {
+			s.logf("error", "failed to deallocate private key memory in Sign", "err", err)
 		}
 	}()
 
@@ -564,9 +697,9 @@ func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string,
 	}
 
 	// call sign function with the pointers to private key and message
-	signaturePtr, err := s.sign.Call(s.ctx, privateKeyPtr, PRIVATE_KEY_SIZE, messagePtr, msgLen)
+	signaturePtr, err := s.sign.Call(ctx, privateKeyPtr, PRIVATE_KEY_SIZE, messagePtr, msgLen)
 	if err != nil {
-		log.Println("sign error:", err)
+		s.logf("error", "sign error", "err", err)
 		return "", errors.New("failed to sign message")
 	}
 	if len(signaturePtr) == 0 {
@@ -577,7 +710,7 @@ func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string,
 		return "", errors.New("internal error when signing message")
 	}
 	if sigLen != SIGNATURE_SIZE {
-		log.Printf("unexpected signature length %d (expected %d)", sigLen, SIGNATURE_SIZE)
+		s.logf("error", "unexpected signature length", "got", sigLen, "expected", SIGNATURE_SIZE)
 	}
 
 	// read signature string from memory
@@ -586,8 +719,8 @@ func (s *aleoWrapperSession) Sign(key []byte, message []byte) (signature string,
 		return "", errors.New("failed to sign message")
 	}
 	defer func(ptr uint32) {
-		if err := s.deallocateSafe(uint64(ptr), 0); err != nil {
-			log.Printf("Failed to deallocate signature memory: %v", err)
+		if err := s.deallocateSafe(ctx, uint64(ptr), 0); err != nil {
+			s.logf("error", "failed to deallocate signature memory", "err", err)
 		}
 	}(sigPtr)
 
@@ -0,0 +1,18 @@
+package aleo_utils
+
+// SessionOption configures a Session created via Wrapper.NewSession.
+type SessionOption func(*sessionOptions)
+
+type sessionOptions struct {
+	signer Signer
+}
+
+// WithSigner overrides the Signer used for private-key operations (NewPrivateKey
+// and Sign) on the Session being created, instead of the default in-WASM
+// implementation. See the Signer and agent.Client docs for why you'd want
+// this, e.g. keeping key material in a separate process, HSM, or enclave.
+func WithSigner(signer Signer) SessionOption {
+	return func(o *sessionOptions) {
+		o.signer = signer
+	}
+}
@@ -0,0 +1,33 @@
+package aleo_utils
+
+import "context"
+
+// Signer performs the private-key-bearing operations of a Session: generating
+// new keys, deriving their Aleo address, and producing Schnorr signatures.
+// Passing a Signer to NewSession via WithSigner lets those operations be
+// delegated to an alternative backend instead of the in-WASM implementation
+// used by default — for example the agent subpackage's Client, which talks to
+// an out-of-process daemon holding the key material.
+//
+// The key returned by NewPrivateKeyContext and later passed to SignContext is
+// only meaningful to the paired Signer: the default wasm signer treats it as
+// the literal PRIVATE_KEY_SIZE-byte private key, while a remote signer may
+// instead return an opaque handle that never leaves its custody boundary.
+type Signer interface {
+	NewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error)
+	SignContext(ctx context.Context, key []byte, message []byte) (signature string, err error)
+}
+
+// wasmSigner is the Signer used by default: it performs private-key
+// operations inside the session's own embedded WASM module instance.
+type wasmSigner struct {
+	session *aleoWrapperSession
+}
+
+func (w *wasmSigner) NewPrivateKeyContext(ctx context.Context) (key []byte, address string, err error) {
+	return w.session.wasmNewPrivateKeyContext(ctx)
+}
+
+func (w *wasmSigner) SignContext(ctx context.Context, key []byte, message []byte) (signature string, err error) {
+	return w.session.wasmSignContext(ctx, key, message)
+}
@@ -7,7 +7,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
-	"log"
+	"os"
 
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
@@ -30,14 +30,21 @@ const (
 // Wrapper is an interface for Aleo Wrapper session manager. Create an instance of a Wrapper using
 // NewWrapper, then create a new Session to use the signing functionality.
 type Wrapper interface {
-	NewSession() (Session, error)
+	NewSession(opts ...SessionOption) (Session, error)
+	// NewPool pre-instantiates size Sessions and returns a goroutine-safe
+	// SessionPool that hands them out via SessionPool.Acquire.
+	NewPool(size int) (*SessionPool, error)
 	Close()
 }
 
-func logString(ctx context.Context, module api.Module, ptr, byteCount uint32) {
-	buf, ok := module.Memory().Read(ptr, byteCount)
-	if ok {
-		log.Println("Aleo Wrapper log:", string(buf))
+// newLogStringHost returns the env.host_log_string import, routing guest log
+// messages through logger instead of the standard library logger directly.
+func newLogStringHost(logger Logger) func(ctx context.Context, module api.Module, ptr, byteCount uint32) {
+	return func(ctx context.Context, module api.Module, ptr, byteCount uint32) {
+		buf, ok := module.Memory().Read(ptr, byteCount)
+		if ok {
+			logger("info", "Aleo Wrapper log", "message", string(buf))
+		}
 	}
 }
 
@@ -48,15 +55,31 @@ type aleoWrapper struct {
 	cmod          wazero.CompiledModule
 	moduleConfig  wazero.ModuleConfig
 	runtimeActive bool // a simple guard against using wrapper after it's runtime was destroyed
+
+	compilationCache    wazero.CompilationCache // nil unless WithCompilationCache was used
+	compilationCacheDir string                  // scratch dir to remove on Close, set only for WithReadOnlyCache
+
+	// logger receives wrapper, session, batch and pool lifecycle/error events
+	// so that WithLogger fully silences/redirects the package. Never nil.
+	logger Logger
 }
 
-// NewWrapper creates Leo contract compatible Schnorr wrapper manager.
+// NewWrapper creates Leo contract compatible Schnorr wrapper manager, using today's defaults
+// (the wazero compiler engine, no persisted compilation cache).
 // The second argument is a cleanup function, which destroys wrapper runtime.
 // aleoWrapper cannot be used after the cleanup function is called, and must be recreated using this function.
 func NewWrapper() (wrapper Wrapper, closeFn func(), err error) {
+	return NewWrapperWithOptions()
+}
+
+// NewWrapperWithOptions creates a Leo contract compatible Schnorr wrapper manager like NewWrapper,
+// but accepts Option values to customize runtime behavior, such as enabling an on-disk compilation
+// cache via WithCompilationCache. The second argument is a cleanup function, which destroys wrapper
+// runtime. aleoWrapper cannot be used after the cleanup function is called, and must be recreated
+// using this function.
+func NewWrapperWithOptions(opts ...Option) (wrapper Wrapper, closeFn func(), err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			// find out exactly what the error was and set err
 			switch x := r.(type) {
 			case string:
 				err = errors.New(x)
@@ -70,9 +93,47 @@ func NewWrapper() (wrapper Wrapper, closeFn func(), err error) {
 		}
 	}()
 
+	o := &wrapperOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	logger := o.logger
+	if logger == nil {
+		logger = defaultLogger
+	}
+
 	ctx := context.Background()
 
-	runtimeConfig := wazero.NewRuntimeConfigCompiler()
+	var runtimeConfig wazero.RuntimeConfig
+	switch o.engine {
+	case EngineInterpreter:
+		runtimeConfig = wazero.NewRuntimeConfigInterpreter()
+	default:
+		runtimeConfig = wazero.NewRuntimeConfigCompiler()
+	}
+	if o.coreFeatures != 0 {
+		runtimeConfig = runtimeConfig.WithCoreFeatures(o.coreFeatures)
+	}
+	cache, cacheTempDir := buildCompilationCache(o, logger)
+	if cache != nil {
+		runtimeConfig = runtimeConfig.WithCompilationCache(cache)
+	}
+	// If we fail later in this function, the cache and its scratch dir (if any)
+	// are otherwise never cleaned up, since no Wrapper exists yet to do it.
+	defer func() {
+		if err != nil {
+			if cache != nil {
+				_ = cache.Close(ctx)
+			}
+			if cacheTempDir != "" {
+				_ = os.RemoveAll(cacheTempDir)
+			}
+		}
+	}()
+	// Allow an api.Function.Call to be aborted by cancelling or timing out the
+	// context.Context passed to it, so Session's context-aware methods (e.g.
+	// SignContext) can enforce deadlines on signing under load.
+	runtimeConfig = runtimeConfig.WithCloseOnContextDone(true)
 	runtime := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
 	// If we fail later in this function, make sure to close the runtime to avoid leaks.
 	defer func() {
@@ -84,9 +145,13 @@ func NewWrapper() (wrapper Wrapper, closeFn func(), err error) {
 	// export some wasi system functions
 	wasi_snapshot_preview1.MustInstantiate(ctx, runtime)
 
-	// export logging function to the guest
+	// export logging function and any user-supplied host functions to the guest
 	hostBuilder := runtime.NewHostModuleBuilder("env")
-	if _, hbErr := hostBuilder.NewFunctionBuilder().WithFunc(logString).Export("host_log_string").Instantiate(ctx); hbErr != nil {
+	hostBuilder.NewFunctionBuilder().WithFunc(newLogStringHost(logger)).Export("host_log_string")
+	for _, name := range sortedKeys(o.hostFunctions) {
+		hostBuilder.NewFunctionBuilder().WithFunc(o.hostFunctions[name]).Export(name)
+	}
+	if _, hbErr := hostBuilder.Instantiate(ctx); hbErr != nil {
 		return nil, nil, fmt.Errorf("failed to instantiate host module: %w", hbErr)
 	}
 
@@ -96,21 +161,25 @@ func NewWrapper() (wrapper Wrapper, closeFn func(), err error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	log.Println("compiled wrapper WASM module")
+	logger("info", "compiled wrapper WASM module")
 
 	wrapper = &aleoWrapper{
-		runtime:       runtime,
-		cmod:          cmod,
-		moduleConfig:  moduleConfig,
-		runtimeActive: true,
+		runtime:             runtime,
+		cmod:                cmod,
+		moduleConfig:        moduleConfig,
+		runtimeActive:       true,
+		compilationCache:    cache,
+		compilationCacheDir: cacheTempDir,
+		logger:              logger,
 	}
 
 	return wrapper, wrapper.Close, nil
 }
 
 // NewSession creates a new wrapper session, which can used to access signing logic. Sessions
-// are not goroutine-safe.
-func (s *aleoWrapper) NewSession() (Session, error) {
+// are not goroutine-safe. By default, private-key operations run inside the session's own
+// WASM module instance; pass WithSigner to delegate them to an alternative Signer instead.
+func (s *aleoWrapper) NewSession(opts ...SessionOption) (Session, error) {
 	if !s.runtimeActive || s.runtime == nil {
 		s.runtime = nil
 		return nil, ErrNoRuntime
@@ -146,9 +215,21 @@ func (s *aleoWrapper) NewSession() (Session, error) {
 		return nil, fmt.Errorf("missing required wasm exports: %v", missing)
 	}
 
+	capabilities, err := negotiateCapabilities(context.Background(), mod, required["dealloc"])
+	if err != nil {
+		_ = mod.Close(context.Background())
+		return nil, fmt.Errorf("failed to negotiate wasm module capabilities: %w", err)
+	}
+
+	so := &sessionOptions{}
+	for _, opt := range opts {
+		opt(so)
+	}
+
 	session := &aleoWrapperSession{
 		mod:              mod,
-		ctx:              context.Background(),
+		logger:           s.logger,
+		capabilities:     capabilities,
 		newPrivateKey:    required["new_private_key"],
 		getAddress:       required["get_address"],
 		sign:             required["sign"],
@@ -158,6 +239,13 @@ func (s *aleoWrapper) NewSession() (Session, error) {
 		hashMessageBytes: required["hash_message_bytes"],
 		formatMessage:    required["format_message"],
 		recoverMessage:   required["formatted_message_to_bytes"],
+		signMany:         mod.ExportedFunction("sign_many"),
+	}
+
+	if so.signer != nil {
+		session.signer = so.signer
+	} else {
+		session.signer = &wasmSigner{session: session}
 	}
 
 	return session, nil
@@ -165,8 +253,15 @@ func (s *aleoWrapper) NewSession() (Session, error) {
 
 // Closes WASM runtime
 func (s *aleoWrapper) Close() {
+	ctx := context.Background()
 	if s.runtime != nil {
-		s.runtime.Close(context.Background())
+		s.runtime.Close(ctx)
+	}
+	if s.compilationCache != nil {
+		_ = s.compilationCache.Close(ctx)
+	}
+	if s.compilationCacheDir != "" {
+		_ = os.RemoveAll(s.compilationCacheDir)
 	}
 	s.runtimeActive = false
 }